@@ -0,0 +1,31 @@
+package main
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// withBasicAuth wraps next with HTTP basic auth when currentConfig.BasicAuth
+// is set, so /vitals and /metrics can be exposed on a public interface
+// safely. If no basic-auth config is present, next is returned unwrapped.
+func withBasicAuth(next http.HandlerFunc) http.HandlerFunc {
+	auth := currentConfig.BasicAuth
+	if auth == nil || auth.Username == "" {
+		return next
+	}
+
+	return func(resp http.ResponseWriter, req *http.Request) {
+		username, password, ok := req.BasicAuth()
+		if !ok || !constantTimeEqual(username, auth.Username) || !constantTimeEqual(password, auth.Password) {
+			resp.Header().Set("WWW-Authenticate", `Basic realm="vitals-glimpse"`)
+			http.Error(resp, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next(resp, req)
+	}
+}
+
+func constantTimeEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
@@ -0,0 +1,33 @@
+package main
+
+// MemStat is a platform-neutral snapshot of memory usage, in bytes.
+type MemStat struct {
+	TotalBytes     uint64
+	AvailableBytes uint64
+	FreeBytes      uint64
+	BuffersBytes   uint64
+	CachedBytes    uint64
+	SwapTotalBytes uint64
+	SwapFreeBytes  uint64
+}
+
+// CPUStat is a cumulative snapshot of CPU tick counters. Usage is derived
+// by the caller from the delta between two samples taken apart in time.
+type CPUStat struct {
+	IdleTicks  int
+	TotalTicks int
+}
+
+// Collector abstracts the OS-specific mechanism used to read memory, CPU,
+// and disk statistics so that vitals-glimpse can run on more than just
+// Linux. Exactly one collector_<goos>.go is compiled in for a given
+// build, and it provides newCollector for that platform.
+type Collector interface {
+	Memory() (MemStat, error)
+	CPU() (CPUStat, error)
+	Disk(mount string) (DiskStat, error)
+}
+
+// activeCollector is selected once at startup for the platform being
+// built.
+var activeCollector = newCollector()
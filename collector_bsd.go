@@ -0,0 +1,32 @@
+//go:build darwin || freebsd
+
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"syscall"
+)
+
+// sysctlUint64 reads a uint64-valued sysctl by name, e.g. "hw.memsize" on
+// Darwin or "hw.physmem" on FreeBSD. Shared by both BSD-derived
+// collectors since syscall.Sysctl is available on each.
+func sysctlUint64(name string) (uint64, error) {
+	raw, err := syscall.Sysctl(name)
+	if err != nil {
+		return 0, fmt.Errorf("sysctl %s: %w", name, err)
+	}
+
+	// syscall.Sysctl trims a single trailing NUL byte, which for a
+	// little-endian fixed-width numeric sysctl is usually the high byte.
+	// Restore it so the buffer is always 8 bytes wide.
+	buf := []byte(raw)
+	if len(buf) == 7 {
+		buf = append(buf, 0)
+	}
+	if len(buf) != 8 {
+		return 0, fmt.Errorf("sysctl %s: unexpected length %d", name, len(buf))
+	}
+
+	return binary.LittleEndian.Uint64(buf), nil
+}
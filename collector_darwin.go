@@ -0,0 +1,101 @@
+package main
+
+/*
+#include <mach/mach.h>
+#include <mach/mach_host.h>
+#include <sys/sysctl.h>
+
+static int vitals_vm_statistics(vm_statistics64_data_t *out) {
+	mach_msg_type_number_t count = HOST_VM_INFO64_COUNT;
+	return host_statistics64(mach_host_self(), HOST_VM_INFO64, (host_info64_t)out, &count);
+}
+
+static int vitals_cpu_load(host_cpu_load_info_data_t *out) {
+	mach_msg_type_number_t count = HOST_CPU_LOAD_INFO_COUNT;
+	return host_statistics(mach_host_self(), HOST_CPU_LOAD_INFO, (host_info_t)out, &count);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// darwinCollector implements Collector via sysctl and the Mach
+// host_statistics interface.
+type darwinCollector struct{}
+
+func newCollector() Collector {
+	return darwinCollector{}
+}
+
+func (darwinCollector) Memory() (MemStat, error) {
+	total, err := sysctlUint64("hw.memsize")
+	if err != nil {
+		return MemStat{}, err
+	}
+
+	var vmStat C.vm_statistics64_data_t
+	if ret := C.vitals_vm_statistics(&vmStat); ret != C.KERN_SUCCESS {
+		return MemStat{}, fmt.Errorf("host_statistics64 failed: %d", ret)
+	}
+
+	pageSize := uint64(syscall.Getpagesize())
+	free := uint64(vmStat.free_count) * pageSize
+	inactive := uint64(vmStat.inactive_count) * pageSize
+	wired := uint64(vmStat.wire_count) * pageSize
+
+	return MemStat{
+		TotalBytes:     total,
+		FreeBytes:      free,
+		AvailableBytes: free + inactive,
+		CachedBytes:    inactive,
+		BuffersBytes:   wired,
+	}, nil
+}
+
+func (darwinCollector) CPU() (CPUStat, error) {
+	var cpuLoad C.host_cpu_load_info_data_t
+	if ret := C.vitals_cpu_load(&cpuLoad); ret != C.KERN_SUCCESS {
+		return CPUStat{}, fmt.Errorf("host_statistics failed: %d", ret)
+	}
+
+	user := int(cpuLoad.cpu_ticks[C.CPU_STATE_USER])
+	system := int(cpuLoad.cpu_ticks[C.CPU_STATE_SYSTEM])
+	idle := int(cpuLoad.cpu_ticks[C.CPU_STATE_IDLE])
+	nice := int(cpuLoad.cpu_ticks[C.CPU_STATE_NICE])
+
+	return CPUStat{IdleTicks: idle, TotalTicks: user + system + idle + nice}, nil
+}
+
+func (darwinCollector) Disk(mount string) (DiskStat, error) {
+	var stat syscall.Statfs_t
+
+	if err := syscall.Statfs(mount, &stat); err != nil {
+		return DiskStat{}, err
+	}
+
+	totalSpace := int(stat.Blocks) * int(stat.Bsize)
+	if totalSpace == 0 {
+		return DiskStat{}, fmt.Errorf("zero total space for mount %s", mount)
+	}
+	availableSpace := int(stat.Bavail) * int(stat.Bsize)
+
+	percent := 99 - int(availableSpace*100/totalSpace)
+
+	status := "disk_okay"
+	if percent >= currentConfig.DiskThreshold {
+		status = "disk_fail"
+	}
+
+	blockSize := uint64(stat.Bsize)
+	return DiskStat{
+		Mount:          mount,
+		Percent:        percent,
+		Status:         status,
+		TotalBytes:     stat.Blocks * blockSize,
+		FreeBytes:      stat.Bfree * blockSize,
+		AvailableBytes: stat.Bavail * blockSize,
+	}, nil
+}
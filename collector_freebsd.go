@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"syscall"
+)
+
+// freebsdCollector implements Collector via sysctl, reading the same
+// kern.cp_time CPU tick array and vm.stats.vm.* counters exposed by
+// FreeBSD's vmstat/top.
+type freebsdCollector struct{}
+
+func newCollector() Collector {
+	return freebsdCollector{}
+}
+
+func (freebsdCollector) Memory() (MemStat, error) {
+	pageSize, err := sysctlUint64("hw.pagesize")
+	if err != nil {
+		return MemStat{}, err
+	}
+
+	total, err := sysctlUint64("hw.physmem")
+	if err != nil {
+		return MemStat{}, err
+	}
+
+	freePages, err := sysctlUint64("vm.stats.vm.v_free_count")
+	if err != nil {
+		return MemStat{}, err
+	}
+	cachePages, err := sysctlUint64("vm.stats.vm.v_cache_count")
+	if err != nil {
+		return MemStat{}, err
+	}
+	inactivePages, err := sysctlUint64("vm.stats.vm.v_inactive_count")
+	if err != nil {
+		return MemStat{}, err
+	}
+
+	free := freePages * pageSize
+	cached := (cachePages + inactivePages) * pageSize
+
+	return MemStat{
+		TotalBytes:     total,
+		FreeBytes:      free,
+		AvailableBytes: free + cached,
+		CachedBytes:    cached,
+	}, nil
+}
+
+func (freebsdCollector) CPU() (CPUStat, error) {
+	raw, err := syscall.Sysctl("kern.cp_time")
+	if err != nil {
+		return CPUStat{}, fmt.Errorf("sysctl kern.cp_time: %w", err)
+	}
+
+	// kern.cp_time is an array of 5 longs: user, nice, system, interrupt,
+	// idle. syscall.Sysctl trims a single trailing NUL byte, so restore it.
+	const numStates = 5
+	buf := []byte(raw)
+	if len(buf) == numStates*8-1 {
+		buf = append(buf, 0)
+	}
+	if len(buf) != numStates*8 {
+		return CPUStat{}, fmt.Errorf("sysctl kern.cp_time: unexpected length %d", len(buf))
+	}
+
+	var times [numStates]uint64
+	for i := range times {
+		times[i] = binary.LittleEndian.Uint64(buf[i*8 : i*8+8])
+	}
+	user, nice, system, interrupt, idle := times[0], times[1], times[2], times[3], times[4]
+
+	return CPUStat{
+		IdleTicks:  int(idle),
+		TotalTicks: int(user + nice + system + interrupt + idle),
+	}, nil
+}
+
+func (freebsdCollector) Disk(mount string) (DiskStat, error) {
+	var stat syscall.Statfs_t
+
+	if err := syscall.Statfs(mount, &stat); err != nil {
+		return DiskStat{}, err
+	}
+
+	totalSpace := int(stat.Blocks) * int(stat.Bsize)
+	if totalSpace == 0 {
+		return DiskStat{}, fmt.Errorf("zero total space for mount %s", mount)
+	}
+	availableSpace := int(stat.Bavail) * int(stat.Bsize)
+
+	percent := 99 - int(availableSpace*100/totalSpace)
+
+	status := "disk_okay"
+	if percent >= currentConfig.DiskThreshold {
+		status = "disk_fail"
+	}
+
+	blockSize := uint64(stat.Bsize)
+	return DiskStat{
+		Mount:          mount,
+		Percent:        percent,
+		Status:         status,
+		TotalBytes:     stat.Blocks * blockSize,
+		FreeBytes:      stat.Bfree * blockSize,
+		AvailableBytes: uint64(stat.Bavail) * blockSize,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// linuxCollector implements Collector by reading /proc and calling
+// syscall.Statfs, the same mechanism vitals-glimpse has always used.
+type linuxCollector struct{}
+
+func newCollector() Collector {
+	return linuxCollector{}
+}
+
+func (linuxCollector) Memory() (MemStat, error) {
+	memInfo, err := os.ReadFile("/proc/meminfo")
+	if err != nil {
+		return MemStat{}, err
+	}
+
+	memStats := make(map[string]int)
+	for _, line := range strings.Split(string(memInfo), "\n") {
+		parts := strings.Fields(line)
+		if len(parts) >= 2 {
+			memStats[parts[0]] = parseInt(parts[1])
+		}
+	}
+
+	return MemStat{
+		TotalBytes:     uint64(memStats["MemTotal:"]) * 1024,
+		AvailableBytes: uint64(memStats["MemAvailable:"]) * 1024,
+		FreeBytes:      uint64(memStats["MemFree:"]) * 1024,
+		BuffersBytes:   uint64(memStats["Buffers:"]) * 1024,
+		CachedBytes:    uint64(memStats["Cached:"]) * 1024,
+		SwapTotalBytes: uint64(memStats["SwapTotal:"]) * 1024,
+		SwapFreeBytes:  uint64(memStats["SwapFree:"]) * 1024,
+	}, nil
+}
+
+func (linuxCollector) CPU() (CPUStat, error) {
+	idle, total := getCPUTimes()
+	return CPUStat{IdleTicks: idle, TotalTicks: total}, nil
+}
+
+func (linuxCollector) Disk(mount string) (DiskStat, error) {
+	var stat syscall.Statfs_t
+
+	if err := syscall.Statfs(mount, &stat); err != nil {
+		return DiskStat{}, err
+	}
+
+	totalSpace := int(stat.Blocks)
+	if totalSpace == 0 {
+		return DiskStat{}, fmt.Errorf("zero total space for mount %s", mount)
+	}
+	availableSpace := int(stat.Bavail)
+
+	percent := 99 - int(availableSpace*100/totalSpace)
+
+	status := "disk_okay"
+	if percent >= currentConfig.DiskThreshold {
+		status = "disk_fail"
+	}
+
+	blockSize := uint64(stat.Bsize)
+	return DiskStat{
+		Mount:          mount,
+		Percent:        percent,
+		Status:         status,
+		TotalBytes:     stat.Blocks * blockSize,
+		FreeBytes:      stat.Bfree * blockSize,
+		AvailableBytes: stat.Bavail * blockSize,
+	}, nil
+}
+
+func parseCPUFields(fields []string) (user, nice, system, idle, iowait, irq, softirq, steal int) {
+	user, _ = strconv.Atoi(fields[1])
+	nice, _ = strconv.Atoi(fields[2])
+	system, _ = strconv.Atoi(fields[3])
+	idle, _ = strconv.Atoi(fields[4])
+	iowait, _ = strconv.Atoi(fields[5])
+	irq, _ = strconv.Atoi(fields[6])
+	softirq, _ = strconv.Atoi(fields[7])
+	steal, _ = strconv.Atoi(fields[8])
+	return
+}
+
+func getCPUTimes() (idleTime, totalTime int) {
+	contents, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		log.Println("Error reading /proc/stat:", err)
+		return
+	}
+
+	lines := strings.Split(string(contents), "\n")
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == "cpu" {
+			user, nice, system, idle, iowait, irq, softirq, steal := parseCPUFields(fields)
+			idleTime = idle + iowait
+			totalTime = user + nice + system + idle + iowait + irq + softirq + steal
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	modkernel32 = syscall.NewLazyDLL("kernel32.dll")
+	modpdh      = syscall.NewLazyDLL("pdh.dll")
+
+	procGlobalMemoryStatusEx = modkernel32.NewProc("GlobalMemoryStatusEx")
+	procGetDiskFreeSpaceExW  = modkernel32.NewProc("GetDiskFreeSpaceExW")
+
+	procPdhOpenQuery           = modpdh.NewProc("PdhOpenQueryW")
+	procPdhAddCounter          = modpdh.NewProc("PdhAddEnglishCounterW")
+	procPdhCollectQueryData    = modpdh.NewProc("PdhCollectQueryData")
+	procPdhGetFormattedCounter = modpdh.NewProc("PdhGetFormattedCounterValue")
+)
+
+// memoryStatusEx mirrors the Win32 MEMORYSTATUSEX struct.
+type memoryStatusEx struct {
+	Length               uint32
+	MemoryLoad           uint32
+	TotalPhys            uint64
+	AvailPhys            uint64
+	TotalPageFile        uint64
+	AvailPageFile        uint64
+	TotalVirtual         uint64
+	AvailVirtual         uint64
+	AvailExtendedVirtual uint64
+}
+
+// windowsCollector implements Collector via the Win32 API and PDH
+// (Performance Data Helper) counters, the standard way to read live
+// system metrics on Windows without shelling out to external tools.
+type windowsCollector struct {
+	cpuQuery syscall.Handle
+
+	// cumulativeTotal/cumulativeUsed turn PDH's instantaneous percentage
+	// into the ever-growing counters CPUStat documents, so the shared
+	// idle/total delta sampler works the same way it does on every other
+	// platform instead of always seeing a zero total delta.
+	mu              sync.Mutex
+	cumulativeTotal int
+	cumulativeUsed  int
+}
+
+func newCollector() Collector {
+	c := &windowsCollector{}
+	if err := c.openCPUQuery(); err != nil {
+		// Fall back to a collector whose CPU() always errors; Memory and
+		// Disk remain usable.
+	}
+	return c
+}
+
+func (c *windowsCollector) openCPUQuery() error {
+	var query syscall.Handle
+	if ret, _, _ := procPdhOpenQuery.Call(0, 0, uintptr(unsafe.Pointer(&query))); ret != 0 {
+		return fmt.Errorf("PdhOpenQuery failed: %#x", ret)
+	}
+
+	counterPath, _ := syscall.UTF16PtrFromString(`\Processor(_Total)\% Processor Time`)
+	var counter syscall.Handle
+	if ret, _, _ := procPdhAddCounter.Call(uintptr(query), uintptr(unsafe.Pointer(counterPath)), 0, uintptr(unsafe.Pointer(&counter))); ret != 0 {
+		return fmt.Errorf("PdhAddEnglishCounter failed: %#x", ret)
+	}
+
+	c.cpuQuery = query
+	return nil
+}
+
+func (*windowsCollector) Memory() (MemStat, error) {
+	var status memoryStatusEx
+	status.Length = uint32(unsafe.Sizeof(status))
+
+	ret, _, err := procGlobalMemoryStatusEx.Call(uintptr(unsafe.Pointer(&status)))
+	if ret == 0 {
+		return MemStat{}, fmt.Errorf("GlobalMemoryStatusEx failed: %w", err)
+	}
+
+	return MemStat{
+		TotalBytes:     status.TotalPhys,
+		AvailableBytes: status.AvailPhys,
+		FreeBytes:      status.AvailPhys,
+		SwapTotalBytes: status.TotalPageFile - status.TotalPhys,
+		SwapFreeBytes:  status.AvailPageFile - status.AvailPhys,
+	}, nil
+}
+
+func (c *windowsCollector) CPU() (CPUStat, error) {
+	if c.cpuQuery == 0 {
+		return CPUStat{}, fmt.Errorf("PDH query not initialized")
+	}
+
+	if ret, _, _ := procPdhCollectQueryData.Call(uintptr(c.cpuQuery)); ret != 0 {
+		return CPUStat{}, fmt.Errorf("PdhCollectQueryData failed: %#x", ret)
+	}
+
+	// PDH reports a live percentage rather than cumulative ticks, so each
+	// call adds a fixed-size window of ticks to a running total, crediting
+	// "used" ticks in proportion to the current percentage. The counters
+	// only grow, matching the cumulative CPUStat contract.
+	const window = 1000
+	percent := pdhFormattedPercent(c)
+	used := int(percent) * window / 100
+
+	c.mu.Lock()
+	c.cumulativeTotal += window
+	c.cumulativeUsed += used
+	total, usedTotal := c.cumulativeTotal, c.cumulativeUsed
+	c.mu.Unlock()
+
+	return CPUStat{IdleTicks: total - usedTotal, TotalTicks: total}, nil
+}
+
+func pdhFormattedPercent(c *windowsCollector) float64 {
+	type pdhFmtCounterValueDouble struct {
+		CStatus     uint32
+		DoubleValue float64
+	}
+
+	var value pdhFmtCounterValueDouble
+	const pdhFmtDouble = 0x00000200
+	procPdhGetFormattedCounter.Call(uintptr(c.cpuQuery), uintptr(pdhFmtDouble), 0, uintptr(unsafe.Pointer(&value)))
+	return value.DoubleValue
+}
+
+func (*windowsCollector) Disk(mount string) (DiskStat, error) {
+	pathPtr, err := syscall.UTF16PtrFromString(mount)
+	if err != nil {
+		return DiskStat{}, err
+	}
+
+	var freeBytesAvail, totalBytes, totalFreeBytes uint64
+
+	ret, _, callErr := procGetDiskFreeSpaceExW.Call(
+		uintptr(unsafe.Pointer(pathPtr)),
+		uintptr(unsafe.Pointer(&freeBytesAvail)),
+		uintptr(unsafe.Pointer(&totalBytes)),
+		uintptr(unsafe.Pointer(&totalFreeBytes)),
+	)
+	if ret == 0 {
+		return DiskStat{}, fmt.Errorf("GetDiskFreeSpaceEx failed: %w", callErr)
+	}
+
+	if totalBytes == 0 {
+		return DiskStat{}, fmt.Errorf("zero total space for mount %s", mount)
+	}
+
+	percent := 99 - int(freeBytesAvail*100/totalBytes)
+
+	status := "disk_okay"
+	if percent >= currentConfig.DiskThreshold {
+		status = "disk_fail"
+	}
+
+	return DiskStat{
+		Mount:          mount,
+		Percent:        percent,
+		Status:         status,
+		TotalBytes:     totalBytes,
+		FreeBytes:      totalFreeBytes,
+		AvailableBytes: freeBytesAvail,
+	}, nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Config holds every user-adjustable setting. It's loaded from a JSON
+// config file (vitals.json by default) with VITALS_* environment
+// variables layered on top, so a container can override a mounted
+// config file without rebuilding it.
+type Config struct {
+	Addr                     string           `json:"addr"`
+	MemThreshold             int              `json:"mem_threshold"`
+	DiskThreshold            int              `json:"disk_threshold"`
+	CpuThreshold             int              `json:"cpu_threshold"`
+	Mounts                   []string         `json:"mounts"`
+	CPUSampleIntervalSeconds int              `json:"cpu_sample_interval_seconds"`
+	TLS                      *TLSConfig       `json:"tls"`
+	BasicAuth                *BasicAuthConfig `json:"basic_auth"`
+}
+
+// TLSConfig switches handleRequests from ListenAndServe to
+// ListenAndServeTLS when both fields are set.
+type TLSConfig struct {
+	CertFile string `json:"cert_file"`
+	KeyFile  string `json:"key_file"`
+}
+
+// BasicAuthConfig, when set, requires HTTP basic auth on /vitals.
+type BasicAuthConfig struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// currentConfig is populated once at startup and read by the rest of
+// the package; it defaults to defaultConfig() so tests that don't call
+// main() still see sane thresholds.
+var currentConfig = defaultConfig()
+
+func defaultConfig() Config {
+	return Config{
+		Addr:                     ":10321",
+		MemThreshold:             90,
+		DiskThreshold:            80,
+		CpuThreshold:             90,
+		Mounts:                   []string{"/"},
+		CPUSampleIntervalSeconds: 1,
+	}
+}
+
+// defaultConfigPath looks for vitals.json in the working directory.
+// YAML isn't supported yet; nothing in this repo parses it without
+// adding a dependency.
+func defaultConfigPath() string {
+	if _, err := os.Stat("vitals.json"); err == nil {
+		return "vitals.json"
+	}
+	return ""
+}
+
+// loadConfig reads configPath (if non-empty) over the defaults, then
+// applies VITALS_* environment variable overrides. A file-read or parse
+// error is returned alongside the defaults/overrides so the caller can
+// log it and keep running.
+func loadConfig(configPath string) (Config, error) {
+	cfg := defaultConfig()
+
+	var loadErr error
+	if configPath != "" {
+		if raw, err := os.ReadFile(configPath); err != nil {
+			loadErr = err
+		} else if err := json.Unmarshal(raw, &cfg); err != nil {
+			loadErr = err
+		}
+	}
+
+	applyEnvOverrides(&cfg)
+
+	// A zero or negative interval would reach time.NewTicker in
+	// startCPUSampler and panic, so clamp it to the default here rather
+	// than trusting the config file/environment to be sane.
+	if cfg.CPUSampleIntervalSeconds <= 0 {
+		cfg.CPUSampleIntervalSeconds = defaultConfig().CPUSampleIntervalSeconds
+	}
+
+	return cfg, loadErr
+}
+
+func applyEnvOverrides(cfg *Config) {
+	if v := os.Getenv("VITALS_ADDR"); v != "" {
+		cfg.Addr = v
+	}
+
+	if v := os.Getenv("VITALS_MOUNTS"); v != "" {
+		mounts := strings.Split(v, ",")
+		for i, mount := range mounts {
+			mounts[i] = strings.TrimSpace(mount)
+		}
+		cfg.Mounts = mounts
+	}
+
+	if v, ok := envInt("VITALS_MEM_THRESHOLD"); ok {
+		cfg.MemThreshold = v
+	}
+	if v, ok := envInt("VITALS_DISK_THRESHOLD"); ok {
+		cfg.DiskThreshold = v
+	}
+	if v, ok := envInt("VITALS_CPU_THRESHOLD"); ok {
+		cfg.CpuThreshold = v
+	}
+}
+
+func envInt(name string) (int, bool) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return 0, false
+	}
+
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, false
+	}
+
+	return v, true
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigAppliesFileThenEnvOverrides(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vitals.json")
+
+	const body = `{"addr":":9999","mem_threshold":70}`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("writing test config: %v", err)
+	}
+
+	t.Setenv("VITALS_MEM_THRESHOLD", "55")
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+
+	if cfg.Addr != ":9999" {
+		t.Errorf("expected addr from file to be kept, got %q", cfg.Addr)
+	}
+	if cfg.MemThreshold != 55 {
+		t.Errorf("expected env override to win, got %d", cfg.MemThreshold)
+	}
+	if cfg.DiskThreshold != defaultConfig().DiskThreshold {
+		t.Errorf("expected unset field to keep its default, got %d", cfg.DiskThreshold)
+	}
+}
+
+func TestLoadConfigClampsNonPositiveCPUSampleInterval(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vitals.json")
+
+	const body = `{"cpu_sample_interval_seconds":0}`
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("writing test config: %v", err)
+	}
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("loadConfig returned error: %v", err)
+	}
+
+	if cfg.CPUSampleIntervalSeconds <= 0 {
+		t.Errorf("expected a non-positive interval to be clamped to a positive default, got %d", cfg.CPUSampleIntervalSeconds)
+	}
+}
+
+func TestLoadConfigMissingFileKeepsDefaults(t *testing.T) {
+	cfg, err := loadConfig(filepath.Join(t.TempDir(), "does-not-exist.json"))
+	if err == nil {
+		t.Fatal("expected an error for a missing config file")
+	}
+
+	want := defaultConfig()
+	if cfg.Addr != want.Addr || cfg.MemThreshold != want.MemThreshold || cfg.DiskThreshold != want.DiskThreshold || cfg.CpuThreshold != want.CpuThreshold {
+		t.Errorf("expected defaults when the config file can't be read, got %+v", cfg)
+	}
+}
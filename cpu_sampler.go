@@ -0,0 +1,73 @@
+package main
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// cpuSampler holds the most recently observed idle/total delta so that
+// HTTP handlers can read a CPU percentage without blocking on a fresh
+// 1-second /proc/stat sample.
+type cpuSampler struct {
+	mu         sync.RWMutex
+	idleDelta  int
+	totalDelta int
+	samples    int
+}
+
+var globalCPUSampler = &cpuSampler{}
+
+// startCPUSampler launches a goroutine that samples the active Collector
+// on interval and updates globalCPUSampler. It never returns.
+func startCPUSampler(interval time.Duration) {
+	go func() {
+		prev, err := activeCollector.CPU()
+		if err != nil {
+			log.Println("Error reading CPU stats:", err)
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			curr, err := activeCollector.CPU()
+			if err != nil {
+				log.Println("Error reading CPU stats:", err)
+				continue
+			}
+
+			idleDelta := curr.IdleTicks - prev.IdleTicks
+			totalDelta := curr.TotalTicks - prev.TotalTicks
+			prev = curr
+
+			if totalDelta < 0 || idleDelta < 0 {
+				// Counter wraparound: drop this interval and resync.
+				continue
+			}
+
+			globalCPUSampler.update(idleDelta, totalDelta)
+		}
+	}()
+}
+
+func (s *cpuSampler) update(idleDelta, totalDelta int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.idleDelta = idleDelta
+	s.totalDelta = totalDelta
+	s.samples++
+}
+
+// currentCPUPercent returns the most recently sampled CPU usage percentage,
+// or -1 if the sampler hasn't collected enough data yet.
+func currentCPUPercent() int {
+	globalCPUSampler.mu.RLock()
+	defer globalCPUSampler.mu.RUnlock()
+
+	if globalCPUSampler.samples < 1 || globalCPUSampler.totalDelta == 0 {
+		return -1
+	}
+
+	return 100 * (globalCPUSampler.totalDelta - globalCPUSampler.idleDelta) / globalCPUSampler.totalDelta
+}
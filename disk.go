@@ -0,0 +1,40 @@
+package main
+
+import (
+	"log"
+)
+
+// DiskStat is the per-mount usage reported in the "disks" array of the
+// /vitals payload.
+type DiskStat struct {
+	Mount          string `json:"mount"`
+	Percent        int    `json:"percent"`
+	Status         string `json:"status"`
+	TotalBytes     uint64 `json:"total_bytes"`
+	FreeBytes      uint64 `json:"free_bytes"`
+	AvailableBytes uint64 `json:"available_bytes"`
+}
+
+// diskMounts returns the configured list of mount points to report on.
+func diskMounts() []string {
+	return currentConfig.Mounts
+}
+
+// diskUsage reports usage for every configured mount point via the
+// active platform Collector.
+func diskUsage() []DiskStat {
+	mounts := diskMounts()
+	stats := make([]DiskStat, 0, len(mounts))
+
+	for _, mount := range mounts {
+		stat, err := activeCollector.Disk(mount)
+		if err != nil {
+			log.Println("Error fetching disk stats for", mount, err)
+			continue
+		}
+
+		stats = append(stats, stat)
+	}
+
+	return stats
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"log"
+	"runtime"
+)
+
+// LoadStat is the load-average block included in both response schemas.
+type LoadStat struct {
+	Load1  float64 `json:"load_1m"`
+	Load5  float64 `json:"load_5m"`
+	Load15 float64 `json:"load_15m"`
+	Status string  `json:"load_status"`
+}
+
+// currentLoadStat reads the current load averages and classifies them
+// against the number of CPUs available: a 5-minute load over 80% of
+// NumCPU is an early warning that CPU% alone won't catch.
+func currentLoadStat() LoadStat {
+	l1, l5, l15, _, _, err := loadAverage()
+	if err != nil {
+		log.Println("Error reading load average:", err)
+		return LoadStat{Status: "load_unknown"}
+	}
+
+	status := "load_okay"
+	if l5 > float64(runtime.NumCPU())*0.8 {
+		status = "load_fail"
+	}
+
+	return LoadStat{Load1: l1, Load5: l5, Load15: l15, Status: status}
+}
+
+func currentUptimeSeconds() float64 {
+	uptime, err := systemUptime()
+	if err != nil {
+		log.Println("Error reading uptime:", err)
+		return -1
+	}
+
+	return uptime.Seconds()
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// loadAverage parses /proc/loadavg, returning the 1/5/15 minute load
+// averages plus the currently-running and total process counts reported
+// alongside them.
+func loadAverage() (l1, l5, l15 float64, running, total int, err error) {
+	contents, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return 0, 0, 0, 0, 0, err
+	}
+
+	fields := strings.Fields(string(contents))
+	if len(fields) < 4 {
+		return 0, 0, 0, 0, 0, fmt.Errorf("unexpected /proc/loadavg format: %q", contents)
+	}
+
+	if l1, err = strconv.ParseFloat(fields[0], 64); err != nil {
+		return 0, 0, 0, 0, 0, err
+	}
+	if l5, err = strconv.ParseFloat(fields[1], 64); err != nil {
+		return 0, 0, 0, 0, 0, err
+	}
+	if l15, err = strconv.ParseFloat(fields[2], 64); err != nil {
+		return 0, 0, 0, 0, 0, err
+	}
+
+	if parts := strings.SplitN(fields[3], "/", 2); len(parts) == 2 {
+		running, _ = strconv.Atoi(parts[0])
+		total, _ = strconv.Atoi(parts[1])
+	}
+
+	return l1, l5, l15, running, total, nil
+}
+
+// systemUptime parses /proc/uptime and returns the time elapsed since
+// boot.
+func systemUptime() (time.Duration, error) {
+	contents, err := os.ReadFile("/proc/uptime")
+	if err != nil {
+		return 0, err
+	}
+
+	fields := strings.Fields(string(contents))
+	if len(fields) < 1 {
+		return 0, fmt.Errorf("unexpected /proc/uptime format: %q", contents)
+	}
+
+	seconds, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// loadAverage isn't implemented outside Linux yet; there's no /proc to
+// parse, and a sysctl/PDH equivalent hasn't been wired up.
+func loadAverage() (l1, l5, l15 float64, running, total int, err error) {
+	return 0, 0, 0, 0, 0, fmt.Errorf("load average not implemented on this platform")
+}
+
+func systemUptime() (time.Duration, error) {
+	return 0, fmt.Errorf("uptime not implemented on this platform")
+}
@@ -1,69 +1,58 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
-	"os"
-	"strconv"
-	"strings"
-	"syscall"
 	"time"
 )
 
+const jsonVersion = "0.2"
 
 func serveStats(resp http.ResponseWriter, req *http.Request) {
-    io.WriteString(resp, statusAsJson())
-}
+	resp.Header().Set("Content-Type", "application/json")
 
+	if requestedSchemaVersion(req) == 1 {
+		io.WriteString(resp, vitalsResponseV1AsJson())
+		return
+	}
 
-func handleRequests() {
-    http.HandleFunc("/vitals", serveStats)
-    log.Fatal(http.ListenAndServe(":10321", nil))
+	io.WriteString(resp, statusAsJson())
 }
 
-func main() {
-	handleRequests()
-}
 
+func handleRequests() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vitals", withBasicAuth(serveStats))
+	mux.HandleFunc("/metrics", withBasicAuth(serveMetrics))
 
-func statusAsJson() string {
-	
-	const jsonVersion = "0.2"
-	const memThresholdPercent = 90
-	const diskThresholdPercent = 80
-	const cpuThresholdPercent = 90
+	if tlsConfig := currentConfig.TLS; tlsConfig != nil && tlsConfig.CertFile != "" && tlsConfig.KeyFile != "" {
+		log.Fatal(http.ListenAndServeTLS(currentConfig.Addr, tlsConfig.CertFile, tlsConfig.KeyFile, mux))
+		return
+	}
 
-	percentMemUsed := percentMemUsed()
-	percentDiskUsed := percentDiskUsed()
-	percentCpuUsed := percentCpuUsed()
+	log.Fatal(http.ListenAndServe(currentConfig.Addr, mux))
+}
 
-	returnString := "{\"title\":\"vitals-glimpse\",\"version\":" + jsonVersion + ","
+func main() {
+	configFlag := flag.String("config", "", "path to a JSON config file (defaults to ./vitals.json if present)")
+	flag.Parse()
 
-	if percentMemUsed < memThresholdPercent {
-		returnString += "\"mem_status\":\"mem_okay\",\"mem_percent\":"
-	} else {
-		returnString += "\"mem_status\":\"mem_fail\",\"mem_percent\":"
-	}
-	returnString += fmt.Sprintf("%d,", percentMemUsed)
-	
-	if percentDiskUsed < diskThresholdPercent {
-		returnString += "\"disk_status\":\"disk_okay\",\"disk_percent\":"
-	} else {
-		returnString += "\"disk_status\":\"disk_fail\",\"disk_percent\":"
+	path := *configFlag
+	if path == "" {
+		path = defaultConfigPath()
 	}
-	returnString += fmt.Sprintf("%d,", percentDiskUsed)
-	
-	if percentCpuUsed < cpuThresholdPercent {
-		returnString += "\"cpu_status\":\"cpu_okay\",\"cpu_percent\":"
-	} else {
-		returnString += "\"cpu_status\":\"cpu_fail\",\"cpu_percent\":"
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		log.Println("Error loading config, falling back to defaults/env overrides:", err)
 	}
-	returnString += fmt.Sprintf("%d}", percentCpuUsed)
-	
+	currentConfig = cfg
 
-	return returnString
+	startCPUSampler(time.Duration(currentConfig.CPUSampleIntervalSeconds) * time.Second)
+	handleRequests()
 }
 
 
@@ -75,92 +64,16 @@ func parseInt(s string) int {
 
 
 func percentMemUsed() int {
-	memInfo, err := os.ReadFile("/proc/meminfo")
+	mem, err := activeCollector.Memory()
 	if err != nil {
-		log.Println("Error reading /proc/meminfo:", err)
+		log.Println("Error reading memory stats:", err)
 		return -1
 	}
 
-	memInfoLines := strings.Split(string(memInfo), "\n")
-	memStats := make(map[string]int)
-
-	for _, line := range memInfoLines {
-			parts := strings.Fields(line)
-			if len(parts) >= 2 {
-				key := parts[0]
-				value := parts[1]
-				memStats[key] = parseInt(value)
-			}
-	}
-
-	percentAvail := float32(memStats["MemAvailable:"])*100/(float32(memStats["MemTotal:"]))
-	return 99-int(percentAvail)
-}
-
-
-func percentDiskUsed() int {
-	var stat syscall.Statfs_t
-
-	err := syscall.Statfs("/", &stat)
-	if err != nil {
-		log.Println("Error fetching Statfs for '/'", err)
+	if mem.TotalBytes == 0 {
 		return -1
 	}
 
-	totalBlocks := stat.Blocks
-	availableBlocks := stat.Bavail
-
-	var totalSpace = int(totalBlocks)
-	if totalSpace == 0 {
-		log.Println("totalSpace unexpectedly zero")
-		totalSpace = -1
-	}
-	availableSpace := int(availableBlocks)
-
-	return 99-int(availableSpace*100/totalSpace)
-}
-
-
-func parseCPUFields(fields []string) (user, nice, system, idle, iowait, irq, softirq, steal int) {
-	user, _ = strconv.Atoi(fields[1])
-	nice, _ = strconv.Atoi(fields[2])
-	system, _ = strconv.Atoi(fields[3])
-	idle, _ = strconv.Atoi(fields[4])
-	iowait, _ = strconv.Atoi(fields[5])
-	irq, _ = strconv.Atoi(fields[6])
-	softirq, _ = strconv.Atoi(fields[7])
-	steal, _ = strconv.Atoi(fields[8])
-	return
-}
-
-func getCPUTimes() (idleTime, totalTime int) {
-	contents, err := os.ReadFile("/proc/stat")
-	if err != nil {
-		log.Println("Error reading /proc/stat:", err)
-		return
-	}
-
-	lines := strings.Split(string(contents), "\n")
-	for _, line := range lines {
-		fields := strings.Fields(line)
-		if len(fields) > 0 && fields[0] == "cpu" {
-			user, nice, system, idle, iowait, irq, softirq, steal := parseCPUFields(fields)
-			idleTime = idle + iowait
-			totalTime = user + nice + system + idle + iowait + irq + softirq + steal
-			return
-		}
-	}
-	return
+	percentAvail := float32(mem.AvailableBytes) * 100 / float32(mem.TotalBytes)
+	return 99 - int(percentAvail)
 }
-
-func percentCpuUsed() int {
-	idleStart, totalStart := getCPUTimes()
-	time.Sleep(1 * time.Second)
-	idleEnd, totalEnd := getCPUTimes()
-
-	idleDelta := idleEnd - idleStart
-	totalDelta := totalEnd - totalStart
-
-	usage := 100 * (totalDelta - idleDelta) / totalDelta
-	return usage
-}
\ No newline at end of file
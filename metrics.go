@@ -0,0 +1,221 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var startPerCPUSamplerOnce sync.Once
+
+func serveMetrics(resp http.ResponseWriter, req *http.Request) {
+	startPerCPUSamplerOnce.Do(func() {
+		startPerCPUSampler(time.Duration(currentConfig.CPUSampleIntervalSeconds) * time.Second)
+	})
+
+	start := time.Now()
+	resp.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	io.WriteString(resp, metricsAsText())
+	fmt.Fprintf(resp, "# HELP vitals_scrape_duration_seconds Time taken to collect this scrape.\n")
+	fmt.Fprintf(resp, "# TYPE vitals_scrape_duration_seconds gauge\n")
+	fmt.Fprintf(resp, "vitals_scrape_duration_seconds %f\n", time.Since(start).Seconds())
+}
+
+func metricsAsText() string {
+	var b strings.Builder
+
+	writeCPUMetrics(&b)
+	writeMemoryMetrics(&b)
+	writeSwapMetrics(&b)
+	writeFilesystemMetrics(&b, diskMounts())
+
+	return b.String()
+}
+
+func writeCPUMetrics(b *strings.Builder) {
+	b.WriteString("# HELP vitals_cpu_usage_ratio Per-CPU usage ratio from the background sampler.\n")
+	b.WriteString("# TYPE vitals_cpu_usage_ratio gauge\n")
+
+	for cpu, ratio := range currentPerCPUUsage() {
+		fmt.Fprintf(b, "vitals_cpu_usage_ratio{cpu=\"%s\"} %f\n", cpu, ratio)
+	}
+}
+
+func writeMemoryMetrics(b *strings.Builder) {
+	memStats, err := readMemInfo()
+	if err != nil {
+		log.Println("Error reading /proc/meminfo:", err)
+		return
+	}
+
+	b.WriteString("# HELP vitals_memory_bytes Memory statistics in bytes.\n")
+	b.WriteString("# TYPE vitals_memory_bytes gauge\n")
+
+	states := []struct {
+		state string
+		key   string
+	}{
+		{"total", "MemTotal:"},
+		{"available", "MemAvailable:"},
+		{"free", "MemFree:"},
+		{"buffers", "Buffers:"},
+		{"cached", "Cached:"},
+	}
+
+	for _, s := range states {
+		fmt.Fprintf(b, "vitals_memory_bytes{state=\"%s\"} %d\n", s.state, memStats[s.key]*1024)
+	}
+}
+
+func writeSwapMetrics(b *strings.Builder) {
+	memStats, err := readMemInfo()
+	if err != nil {
+		log.Println("Error reading /proc/meminfo:", err)
+		return
+	}
+
+	b.WriteString("# HELP vitals_swap_bytes Swap statistics in bytes.\n")
+	b.WriteString("# TYPE vitals_swap_bytes gauge\n")
+
+	fmt.Fprintf(b, "vitals_swap_bytes{state=\"total\"} %d\n", memStats["SwapTotal:"]*1024)
+	fmt.Fprintf(b, "vitals_swap_bytes{state=\"free\"} %d\n", memStats["SwapFree:"]*1024)
+}
+
+func writeFilesystemMetrics(b *strings.Builder, mounts []string) {
+	b.WriteString("# HELP vitals_filesystem_bytes Filesystem space in bytes.\n")
+	b.WriteString("# TYPE vitals_filesystem_bytes gauge\n")
+
+	for _, mount := range mounts {
+		stat, err := activeCollector.Disk(mount)
+		if err != nil {
+			log.Println("Error fetching disk stats for", mount, err)
+			continue
+		}
+
+		fmt.Fprintf(b, "vitals_filesystem_bytes{mount=\"%s\",state=\"total\"} %d\n", mount, stat.TotalBytes)
+		fmt.Fprintf(b, "vitals_filesystem_bytes{mount=\"%s\",state=\"free\"} %d\n", mount, stat.FreeBytes)
+		fmt.Fprintf(b, "vitals_filesystem_bytes{mount=\"%s\",state=\"avail\"} %d\n", mount, stat.AvailableBytes)
+	}
+}
+
+// readMemInfo parses /proc/meminfo into a map of its field names (including
+// the trailing colon) to the kB value reported for that field.
+func readMemInfo() (map[string]int, error) {
+	memInfo, err := os.ReadFile("/proc/meminfo")
+	if err != nil {
+		return nil, err
+	}
+
+	memStats := make(map[string]int)
+	for _, line := range strings.Split(string(memInfo), "\n") {
+		parts := strings.Fields(line)
+		if len(parts) >= 2 {
+			memStats[parts[0]] = parseInt(parts[1])
+		}
+	}
+
+	return memStats, nil
+}
+
+// perCPUSampler holds the most recently observed per-cpuN usage ratio so
+// /metrics scrapes don't block on a fresh 1-second /proc/stat sample, the
+// same non-blocking approach cpuSampler uses for the whole-system figure.
+type perCPUSampler struct {
+	mu    sync.RWMutex
+	usage map[string]float64
+}
+
+var globalPerCPUSampler = &perCPUSampler{}
+
+// startPerCPUSampler launches a goroutine that samples every cpuN line in
+// /proc/stat on interval and updates globalPerCPUSampler. It never returns.
+func startPerCPUSampler(interval time.Duration) {
+	go func() {
+		prev, err := readPerCPUTimes()
+		if err != nil {
+			log.Println("Error reading /proc/stat:", err)
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			curr, err := readPerCPUTimes()
+			if err != nil {
+				log.Println("Error reading /proc/stat:", err)
+				continue
+			}
+
+			usage := make(map[string]float64, len(curr))
+			for cpu, currTimes := range curr {
+				prevTimes, ok := prev[cpu]
+				if !ok {
+					continue
+				}
+
+				idleDelta := currTimes.idle - prevTimes.idle
+				totalDelta := currTimes.total - prevTimes.total
+				if totalDelta <= 0 {
+					// Counter wraparound: drop this cpu for this interval.
+					continue
+				}
+
+				usage[cpu] = float64(totalDelta-idleDelta) / float64(totalDelta)
+			}
+
+			prev = curr
+			globalPerCPUSampler.update(usage)
+		}
+	}()
+}
+
+func (s *perCPUSampler) update(usage map[string]float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.usage = usage
+}
+
+// currentPerCPUUsage returns the most recently sampled per-cpuN usage
+// ratios, or nil if the sampler hasn't collected enough data yet.
+func currentPerCPUUsage() map[string]float64 {
+	globalPerCPUSampler.mu.RLock()
+	defer globalPerCPUSampler.mu.RUnlock()
+	return globalPerCPUSampler.usage
+}
+
+type cpuTimes struct {
+	idle  int
+	total int
+}
+
+// readPerCPUTimes parses every "cpuN" line in /proc/stat (the aggregate
+// "cpu" line is skipped; that's covered by percentCpuUsed).
+func readPerCPUTimes() (map[string]cpuTimes, error) {
+	contents, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return nil, err
+	}
+
+	times := make(map[string]cpuTimes)
+	for _, line := range strings.Split(string(contents), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] == "cpu" || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+
+		user, nice, system, idle, iowait, irq, softirq, steal := parseCPUFields(fields)
+		times[fields[0]] = cpuTimes{
+			idle:  idle + iowait,
+			total: user + nice + system + idle + iowait + irq + softirq + steal,
+		}
+	}
+
+	return times, nil
+}
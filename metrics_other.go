@@ -0,0 +1,11 @@
+//go:build !linux
+
+package main
+
+import "net/http"
+
+// serveMetrics on non-Linux platforms reports that the per-CPU/per-mount
+// Prometheus exposition isn't implemented for this OS yet.
+func serveMetrics(resp http.ResponseWriter, req *http.Request) {
+	http.Error(resp, "metrics not implemented on this platform", http.StatusNotImplemented)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Component is a generic status/percent/threshold block used by the v1
+// schema for memory and CPU.
+type Component struct {
+	Status      string `json:"status"`
+	Percent     int    `json:"percent"`
+	Threshold   int    `json:"threshold"`
+	DetailBytes uint64 `json:"detail_bytes"`
+}
+
+// VitalsResponse is the v1 /vitals schema. It's typed and versioned so it
+// can evolve without breaking v0 clients pinned to the legacy flat shape.
+type VitalsResponse struct {
+	Title         string     `json:"title"`
+	Version       string     `json:"version"`
+	Memory        Component  `json:"memory"`
+	Disks         []DiskStat `json:"disks"`
+	CPU           Component  `json:"cpu"`
+	Load          LoadStat   `json:"load"`
+	UptimeSeconds float64    `json:"uptime_seconds"`
+	Timestamp     time.Time  `json:"timestamp"`
+}
+
+// legacyVitalsResponse is the v0 schema, kept field-for-field compatible
+// with the original hand-rolled string concatenation but built through
+// encoding/json so a stray character in jsonVersion can no longer produce
+// invalid JSON.
+type legacyVitalsResponse struct {
+	Title         string     `json:"title"`
+	Version       string     `json:"version"`
+	MemStatus     string     `json:"mem_status"`
+	MemPercent    int        `json:"mem_percent"`
+	Disks         []DiskStat `json:"disks"`
+	CPUStatus     string     `json:"cpu_status"`
+	CPUPercent    int        `json:"cpu_percent"`
+	Load1         float64    `json:"load_1m"`
+	Load5         float64    `json:"load_5m"`
+	Load15        float64    `json:"load_15m"`
+	LoadStatus    string     `json:"load_status"`
+	UptimeSeconds float64    `json:"uptime_seconds"`
+}
+
+// requestedSchemaVersion reads the ?v= query parameter, falling back to
+// an Accept header of the form "application/vnd.vitals.v1+json", and
+// defaults to the legacy v0 schema.
+func requestedSchemaVersion(req *http.Request) int {
+	if raw := req.URL.Query().Get("v"); raw != "" {
+		if v, err := strconv.Atoi(raw); err == nil {
+			return v
+		}
+	}
+
+	if strings.Contains(req.Header.Get("Accept"), "vnd.vitals.v1") {
+		return 1
+	}
+
+	return 0
+}
+
+func statusAsJson() string {
+	percentMemUsed := percentMemUsed()
+	percentCpuUsed := currentCPUPercent()
+
+	memStatus := "mem_okay"
+	if percentMemUsed >= currentConfig.MemThreshold {
+		memStatus = "mem_fail"
+	}
+
+	cpuStatus := "cpu_okay"
+	if percentCpuUsed >= currentConfig.CpuThreshold {
+		cpuStatus = "cpu_fail"
+	}
+
+	load := currentLoadStat()
+
+	response := legacyVitalsResponse{
+		Title:         "vitals-glimpse",
+		Version:       jsonVersion,
+		MemStatus:     memStatus,
+		MemPercent:    percentMemUsed,
+		Disks:         diskUsage(),
+		CPUStatus:     cpuStatus,
+		CPUPercent:    percentCpuUsed,
+		Load1:         load.Load1,
+		Load5:         load.Load5,
+		Load15:        load.Load15,
+		LoadStatus:    load.Status,
+		UptimeSeconds: currentUptimeSeconds(),
+	}
+
+	raw, err := json.Marshal(response)
+	if err != nil {
+		log.Println("Error marshalling vitals response:", err)
+		return "{}"
+	}
+
+	return string(raw)
+}
+
+func buildVitalsResponse() VitalsResponse {
+	mem, err := activeCollector.Memory()
+	if err != nil {
+		log.Println("Error reading memory stats:", err)
+	}
+
+	percentMemUsed := percentMemUsed()
+	memStatus := "mem_okay"
+	if percentMemUsed >= currentConfig.MemThreshold {
+		memStatus = "mem_fail"
+	}
+
+	percentCpuUsed := currentCPUPercent()
+	cpuStatus := "cpu_okay"
+	if percentCpuUsed >= currentConfig.CpuThreshold {
+		cpuStatus = "cpu_fail"
+	}
+
+	return VitalsResponse{
+		Title:   "vitals-glimpse",
+		Version: "1",
+		Memory: Component{
+			Status:      memStatus,
+			Percent:     percentMemUsed,
+			Threshold:   currentConfig.MemThreshold,
+			DetailBytes: mem.TotalBytes - mem.AvailableBytes,
+		},
+		Disks: diskUsage(),
+		CPU: Component{
+			Status:    cpuStatus,
+			Percent:   percentCpuUsed,
+			Threshold: currentConfig.CpuThreshold,
+		},
+		Load:          currentLoadStat(),
+		UptimeSeconds: currentUptimeSeconds(),
+		Timestamp:     time.Now(),
+	}
+}
+
+func vitalsResponseV1AsJson() string {
+	raw, err := json.Marshal(buildVitalsResponse())
+	if err != nil {
+		log.Println("Error marshalling v1 vitals response:", err)
+		return "{}"
+	}
+
+	return string(raw)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStatusAsJsonRoundTrips(t *testing.T) {
+	raw := statusAsJson()
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+		t.Fatalf("statusAsJson produced invalid JSON: %v\n%s", err, raw)
+	}
+
+	for _, key := range []string{"title", "version", "mem_status", "mem_percent", "disks", "cpu_status", "cpu_percent"} {
+		if _, ok := decoded[key]; !ok {
+			t.Errorf("expected key %q in v0 response, got %v", key, decoded)
+		}
+	}
+}
+
+func TestVitalsResponseV1RoundTrips(t *testing.T) {
+	raw, err := json.Marshal(buildVitalsResponse())
+	if err != nil {
+		t.Fatalf("marshalling v1 response: %v", err)
+	}
+
+	var decoded VitalsResponse
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("v1 response did not round-trip: %v\n%s", err, raw)
+	}
+
+	if decoded.Title != "vitals-glimpse" {
+		t.Errorf("expected title vitals-glimpse, got %q", decoded.Title)
+	}
+}
+
+func TestServeStatsVersionNegotiation(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/vitals?v=1", nil)
+	resp := httptest.NewRecorder()
+
+	serveStats(resp, req)
+
+	var decoded VitalsResponse
+	if err := json.Unmarshal(resp.Body.Bytes(), &decoded); err != nil {
+		t.Fatalf("expected v1 schema for ?v=1, got invalid JSON: %v\n%s", err, resp.Body.String())
+	}
+}